@@ -0,0 +1,36 @@
+package nunc
+
+// Aggregator combines the independently computed per-dimension NUNC costs of
+// a multivariate observation into a single scalar cost, which is then
+// compared against the NUNC's threshold.
+type Aggregator func(costs []float64) float64
+
+// AggregatorSum combines per-dimension costs by summing them.
+func AggregatorSum(costs []float64) float64 {
+	sum := 0.0
+	for _, c := range costs {
+		sum += c
+	}
+	return sum
+}
+
+// AggregatorMax combines per-dimension costs by taking the largest of them,
+// so that a change in any single dimension is enough to drive the aggregate
+// cost.
+func AggregatorMax(costs []float64) float64 {
+	max := 0.0
+	for _, c := range costs {
+		if c > max {
+			max = c
+		}
+	}
+	return max
+}
+
+// AggregatorBonferroniSum combines per-dimension costs via a Bonferroni-style
+// corrected sum: the raw sum of costs is multiplied by the number of
+// dimensions. This scales the aggregate cost up, so against the same
+// threshold it crosses more readily than AggregatorSum does.
+func AggregatorBonferroniSum(costs []float64) float64 {
+	return AggregatorSum(costs) * float64(len(costs))
+}
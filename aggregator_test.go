@@ -0,0 +1,27 @@
+package nunc
+
+import "testing"
+
+func TestAggregatorSum(t *testing.T) {
+	if got := AggregatorSum([]float64{1, 2, 3}); got != 6 {
+		t.Fatalf("AggregatorSum = %f, want 6", got)
+	}
+	if got := AggregatorSum(nil); got != 0 {
+		t.Fatalf("AggregatorSum(nil) = %f, want 0", got)
+	}
+}
+
+func TestAggregatorMax(t *testing.T) {
+	if got := AggregatorMax([]float64{1, 5, 3}); got != 5 {
+		t.Fatalf("AggregatorMax = %f, want 5", got)
+	}
+	if got := AggregatorMax(nil); got != 0 {
+		t.Fatalf("AggregatorMax(nil) = %f, want 0", got)
+	}
+}
+
+func TestAggregatorBonferroniSum(t *testing.T) {
+	if got := AggregatorBonferroniSum([]float64{1, 2, 3}); got != 18 {
+		t.Fatalf("AggregatorBonferroniSum = %f, want 18", got)
+	}
+}
@@ -53,7 +53,13 @@ latency distribution between 2 contactable points via a modified ping command`,
 				pinger.Interval = time.Duration(interval * int(time.Millisecond))
 			}
 
-			nunc, err := nunc.NewNUNC(windowSize, quantiles, nunc.OptThresholdEstimate(falseProbability))
+			nunc, err := nunc.NewNUNC(windowSize, quantiles, nunc.OptThresholdEstimate(falseProbability),
+				nunc.OptOnCost(func(index uint64, cost, threshold float64) {
+					if verbose {
+						printChan <- fmt.Sprintf("Cost at %d: %f (threshold: %f)", index, cost, threshold)
+					}
+				}),
+			)
 			if err != nil {
 				panic(err)
 			}
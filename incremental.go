@@ -0,0 +1,131 @@
+package nunc
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// IncrementalProcessor is a NUNC cost processor implementing the same
+// Process contract as NuncProcessor, but without its per-push O(W log W)
+// copy-and-sort. Instead, it maintains an order statistic skip list across
+// pushes: each push performs one insert and one delete against the skip list
+// in O(log W), and quantile and ECDF lookups query the skip list directly in
+// O(log W) each rather than rebuilding a sorted copy of the window. The
+// O(W*Q) segment-cost scan itself is unchanged from NuncProcessor.
+type IncrementalProcessor struct {
+	window *Window[float64]
+	stats  *orderStatSkipList
+	lock   sync.Mutex
+}
+
+var _ Processor = (*IncrementalProcessor)(nil)
+
+// NewIncremental returns a new incremental NUNC processor with the given
+// window size. If the window size is not valid, an error is returned.
+func NewIncremental(windowSize int) (*IncrementalProcessor, error) {
+	w, err := NewWindow[float64](windowSize)
+	if err != nil {
+		return nil, err
+	}
+	return &IncrementalProcessor{
+		window: w,
+		stats:  newOrderStatSkipList(),
+	}, nil
+}
+
+// Process performs the NUNC logic on the datapoint presented as the value.
+// Returned is the cost associated with the datapoint if one can be computed.
+// If a cost can not be computed, an error is returned. This can safely be
+// used from multiple routines: unlike Window, orderStatSkipList does not
+// guard its own mutations, so Process holds a lock around the window push
+// and the skip list updates and reads it drives.
+func (proc *IncrementalProcessor) Process(value float64, quantileCount int) (*Cost, error) {
+	proc.lock.Lock()
+	defer proc.lock.Unlock()
+
+	evicted, evicting := proc.window.Oldest()
+	count, dataset := proc.window.PushGetFull(value)
+	proc.stats.Insert(value)
+	if evicting {
+		proc.stats.Delete(evicted)
+	}
+	if dataset == nil {
+		return nil, fmt.Errorf("window is not yet fully populated")
+	}
+
+	// get quantiles
+	q := incrementalQuantiles(proc.stats, quantileCount)
+
+	// get cdf values and full cost
+	fullCDF := make([]float64, len(q))
+	fullCost := 0.0
+	for i := 0; i < len(q); i++ {
+		fullCDF[i] = incrementalECDF(proc.stats, q[i])
+		fullCost += cdfCost(fullCDF[i], proc.stats.Len())
+	}
+
+	// loop to calculate segment costs
+	rightCDF := make([]float64, len(fullCDF))
+	leftCDF := make([]float64, len(q))
+	copy(rightCDF, fullCDF)
+	maxCost := new(Cost)
+	for i := 0; i < len(dataset); i++ {
+		length := len(dataset) - i
+		rightCDF = windowUpdate(dataset[i], length, q, rightCDF)
+		length -= 1
+		for j := 0; j < len(q); j++ {
+			leftCDF[j] = ((fullCDF[j] * float64(len(dataset))) - (rightCDF[j] * float64(length))) / (float64(len(dataset) - length))
+		}
+		leftCost := 0.0
+		for _, c := range leftCDF {
+			leftCost += cdfCost(c, len(dataset)-length)
+		}
+		rightCost := 0.0
+		for _, c := range rightCDF {
+			rightCost += cdfCost(c, length)
+		}
+		cost := 2.0 * (leftCost + rightCost - fullCost)
+		if cost > maxCost.value {
+			maxCost.dataIndex = count - 1 - uint64(proc.window.Cap()) + uint64(i)
+			maxCost.value = cost
+		}
+	}
+
+	return maxCost, nil
+}
+
+// incrementalQuantiles mirrors quantiles(), but reads order statistics from
+// stats in O(log W) per quantile instead of indexing into a freshly sorted
+// copy of the window.
+func incrementalQuantiles(stats *orderStatSkipList, quantileCount int) []float64 {
+	n := stats.Len()
+	quantiles := make([]float64, quantileCount)
+	c := math.Log(float64((2 * n) - 1))
+	for i := 0; i < quantileCount; i++ {
+		pct := 1.0 / (1.0 + (2.0*(float64(n)-1.0))*math.Exp((-c/float64(quantileCount))*(2.0*float64(i)-1.0)))
+		quantiles[i] = incrementalQuantile(stats, pct)
+	}
+	return quantiles
+}
+
+// incrementalQuantile mirrors quantile(), selecting order statistics from
+// stats rather than indexing a sorted slice.
+func incrementalQuantile(stats *orderStatSkipList, pct float64) float64 {
+	n := stats.Len()
+	index := float64(n-1) * pct
+	lower := stats.Select(int(math.Floor(index)))
+	upper := stats.Select(int(math.Ceil(index)))
+	if lower == upper {
+		return lower
+	}
+	return lower + ((index - math.Floor(index)) * (upper - lower))
+}
+
+// incrementalECDF mirrors ecdf(), using the skip list's O(log W) rank
+// queries in place of bisecting a sorted slice.
+func incrementalECDF(stats *orderStatSkipList, quantile float64) float64 {
+	left := stats.CountLess(quantile)
+	right := stats.CountLessOrEqual(quantile)
+	return (float64(left) + float64((right-left)/2)) / float64(stats.Len())
+}
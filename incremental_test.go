@@ -0,0 +1,47 @@
+package nunc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestIncrementalProcessorMatchesNaive is a property test asserting that
+// IncrementalProcessor produces identical (Index, Value) output to
+// NuncProcessor, the naive reference implementation, when fed the same
+// stream, including across a distribution shift partway through.
+func TestIncrementalProcessorMatchesNaive(t *testing.T) {
+	const windowSize = 50
+
+	naive, err := New(windowSize)
+	if err != nil {
+		t.Fatalf("failed to create naive processor: %s", err.Error())
+	}
+	inc, err := NewIncremental(windowSize)
+	if err != nil {
+		t.Fatalf("failed to create incremental processor: %s", err.Error())
+	}
+
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 500; i++ {
+		v := r.NormFloat64()
+		if i >= 250 {
+			v += 10
+		}
+
+		naiveCost, naiveErr := naive.Process(v, 4)
+		incCost, incErr := inc.Process(v, 4)
+
+		if (naiveErr == nil) != (incErr == nil) {
+			t.Fatalf("iter %d: error mismatch, naive=%v incremental=%v", i, naiveErr, incErr)
+		}
+		if naiveErr != nil {
+			continue
+		}
+		if naiveCost.Index() != incCost.Index() {
+			t.Fatalf("iter %d: index mismatch, naive=%d incremental=%d", i, naiveCost.Index(), incCost.Index())
+		}
+		if diff := naiveCost.Value() - incCost.Value(); diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("iter %d: value mismatch, naive=%v incremental=%v", i, naiveCost.Value(), incCost.Value())
+		}
+	}
+}
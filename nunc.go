@@ -7,15 +7,32 @@ import (
 )
 
 // NUNC is a container for the NUNC changepoint detection algorithm logic and
-// configuration.
+// configuration. A NUNC created with NewNUNC operates on a single scalar
+// stream via Push. A NUNC created with NewNUNCMulti instead operates on
+// vector-valued observations via PushVec, running one processor per
+// dimension and combining their costs with an Aggregator.
 type NUNC struct {
-	processor *NuncProcessor
-	threshold Threshold
+	processor  Processor
+	processors []Processor
+	threshold  Threshold
+	aggregator Aggregator
 
 	windowSize int
 	quantiles  int
+	dim        int
 
 	changepoints []uint64
+
+	onCost        func(index uint64, cost, threshold float64)
+	onChangepoint func(cp Changepoint)
+}
+
+// Changepoint describes a changepoint detected by a NUNC: the datapoint
+// index it is estimated to have occurred at, and the cost that triggered its
+// detection.
+type Changepoint struct {
+	Index uint64
+	Cost  float64
 }
 
 // NuncOpt is a generic configuration option for the NUNC algorithm.
@@ -37,6 +54,45 @@ func NewNUNC(windowSize, quantiles int, opts ...NuncOpt) (*NUNC, error) {
 
 		windowSize: windowSize,
 		quantiles:  quantiles,
+		dim:        1,
+
+		changepoints: make([]uint64, 0),
+	}
+	for idx, opt := range opts {
+		if err := opt(n); err != nil {
+			return nil, fmt.Errorf("failed to apply opt %d: %s", idx, err.Error())
+		}
+	}
+	return n, nil
+}
+
+// NewNUNCMulti creates a new nunc that detects changepoints across a
+// vector-valued data stream of the given dimensionality. Each dimension is
+// processed independently by its own processor using the shared window size
+// and quantiles configuration, and the per-dimension costs are combined with
+// an Aggregator (AggregatorSum by default, see OptAggregator) before being
+// compared to the threshold. If the configuration is in any way invalid, an
+// error is returned.
+func NewNUNCMulti(windowSize, quantiles, dim int, opts ...NuncOpt) (*NUNC, error) {
+	if dim <= 0 {
+		return nil, fmt.Errorf("dimension must be greater than 0")
+	}
+	processors := make([]Processor, dim)
+	for i := 0; i < dim; i++ {
+		processor, err := New(windowSize)
+		if err != nil {
+			return nil, err
+		}
+		processors[i] = processor
+	}
+	n := &NUNC{
+		processors: processors,
+		threshold:  nil,
+		aggregator: AggregatorSum,
+
+		windowSize: windowSize,
+		quantiles:  quantiles,
+		dim:        dim,
 
 		changepoints: make([]uint64, 0),
 	}
@@ -55,10 +111,12 @@ func NewNUNC(windowSize, quantiles int, opts ...NuncOpt) (*NUNC, error) {
 // suppressed and 0 is returned.
 func (n *NUNC) Push(datapoint float64) uint64 {
 	if cost, err := n.processor.Process(datapoint, n.quantiles); err == nil && cost != nil {
+		n.notifyCost(cost.Index(), cost.Value())
 		if n.threshold != nil {
 			if change, err := n.threshold.Changepoint(cost.Value()); err == nil && change {
 				if ok := slices.Contains(n.changepoints, cost.Index()); !ok {
 					n.changepoints = append(n.changepoints, cost.Index())
+					n.notifyChangepoint(cost.Index(), cost.Value())
 					return cost.Index()
 				}
 			}
@@ -67,6 +125,73 @@ func (n *NUNC) Push(datapoint float64) uint64 {
 	return 0
 }
 
+// PushVec adds a new vector-valued datapoint to a multivariate NUNC created
+// via NewNUNCMulti. Each element of v is processed independently by its
+// dimension's processor, and the resulting per-dimension costs are combined
+// by the configured Aggregator before being compared to the threshold. If v
+// does not have exactly as many elements as the NUNC's dimensionality, or any
+// dimension's window is not yet fully populated, 0 is returned. Otherwise
+// this behaves as Push: the index of a newly detected changepoint is
+// returned, or 0 if none was detected.
+func (n *NUNC) PushVec(v []float64) uint64 {
+	if len(v) != n.dim {
+		return 0
+	}
+	costs := make([]float64, n.dim)
+	var index uint64
+	bestCost := -1.0
+	ready := true
+	for i, value := range v {
+		cost, err := n.processors[i].Process(value, n.quantiles)
+		if err != nil || cost == nil {
+			ready = false
+			continue
+		}
+		costs[i] = cost.Value()
+		if cost.Value() > bestCost {
+			bestCost = cost.Value()
+			index = cost.Index()
+		}
+	}
+	if !ready {
+		return 0
+	}
+	aggregate := n.aggregator(costs)
+	n.notifyCost(index, aggregate)
+	if n.threshold != nil {
+		if change, err := n.threshold.Changepoint(aggregate); err == nil && change {
+			if ok := slices.Contains(n.changepoints, index); !ok {
+				n.changepoints = append(n.changepoints, index)
+				n.notifyChangepoint(index, aggregate)
+				return index
+			}
+		}
+	}
+	return 0
+}
+
+// notifyCost invokes the OptOnCost callback, if configured, with the given
+// index and cost alongside the nunc's current threshold value.
+func (n *NUNC) notifyCost(index uint64, cost float64) {
+	if n.onCost == nil {
+		return
+	}
+	threshold := 0.0
+	if n.threshold != nil {
+		threshold = n.threshold.Value()
+	}
+	n.onCost(index, cost, threshold)
+}
+
+// notifyChangepoint invokes the OptOnChangepoint callback, if configured,
+// with the newly detected changepoint.
+func (n *NUNC) notifyChangepoint(index uint64, cost float64) {
+	if n.onChangepoint == nil {
+		return
+	}
+	n.onChangepoint(Changepoint{Index: index, Cost: cost})
+}
+
 // Threshold returns the current value used as the maximum cost threshold where,
 // if exceeded, a datapoint is considered to be a changepoint.
 func (n NUNC) Threshold() float64 {
@@ -84,7 +209,7 @@ func (n NUNC) Changepoints() []uint64 {
 // 1000 datapoints must be provided.
 func OptThresholdEstimate(probability float64) NuncOpt {
 	return func(n *NUNC) error {
-		threshold, err := NewThresholdEstimate(probability, 1000, n.windowSize, n.quantiles)
+		threshold, err := NewThresholdEstimate(probability, 1000, n.windowSize, n.quantiles, n.dim)
 		if err != nil {
 			return err
 		}
@@ -100,3 +225,55 @@ func OptThreshold(value float64) NuncOpt {
 		return nil
 	}
 }
+
+// OptThresholdAdaptive configures an adaptive threshold that learns from the
+// nunc's own recent cost history instead of a fixed or pre-estimated value.
+// costWindowSize sets how many past costs are retained, thresholdPct sets
+// the quantile of that history used as the live threshold, and warmup is the
+// number of cost observations required before the threshold becomes active.
+// Costs that are themselves flagged as changepoints are not fed back into
+// the cost history, so a real distribution shift does not drag the
+// threshold upward behind it.
+func OptThresholdAdaptive(costWindowSize int, thresholdPct float64, warmup int) NuncOpt {
+	return func(n *NUNC) error {
+		threshold, err := NewThresholdAdaptive(costWindowSize, thresholdPct, warmup)
+		if err != nil {
+			return err
+		}
+		n.threshold = threshold
+		return nil
+	}
+}
+
+// OptAggregator sets the Aggregator used by a multivariate NUNC to combine
+// its per-dimension costs before comparing them to the threshold. It has no
+// effect on a NUNC created with NewNUNC. If not set, NewNUNCMulti defaults to
+// AggregatorSum.
+func OptAggregator(aggregator Aggregator) NuncOpt {
+	return func(n *NUNC) error {
+		n.aggregator = aggregator
+		return nil
+	}
+}
+
+// OptOnCost registers a callback that is invoked with every cost computed by
+// the nunc, alongside the datapoint index it was computed for and the
+// threshold value it was compared against (0 if no threshold is configured).
+// This lets callers plot cost against threshold over time, export costs to
+// Prometheus, or tune OptThresholdEstimate's probability empirically,
+// without the nunc having to retain the costs itself.
+func OptOnCost(fn func(index uint64, cost, threshold float64)) NuncOpt {
+	return func(n *NUNC) error {
+		n.onCost = fn
+		return nil
+	}
+}
+
+// OptOnChangepoint registers a callback that is invoked whenever the nunc
+// detects a new changepoint.
+func OptOnChangepoint(fn func(cp Changepoint)) NuncOpt {
+	return func(n *NUNC) error {
+		n.onChangepoint = fn
+		return nil
+	}
+}
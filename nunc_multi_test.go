@@ -0,0 +1,75 @@
+package nunc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewNUNCMultiRejectsNonPositiveDim(t *testing.T) {
+	if _, err := NewNUNCMulti(50, 4, 0); err == nil {
+		t.Fatal("expected an error for dim <= 0")
+	}
+}
+
+// TestNUNCMultiDimensionsFillInLockstep checks that every dimension's
+// processor is pushed unconditionally on every call. If PushVec were to
+// stop processing the remaining dimensions as soon as one dimension's window
+// isn't yet full, each dimension would fill its window over a different
+// slice of calls, and the first aggregate cost would be delayed well past
+// windowSize calls.
+func TestNUNCMultiDimensionsFillInLockstep(t *testing.T) {
+	const windowSize = 5
+	firstCostCall := -1
+	calls := 0
+
+	n, err := NewNUNCMulti(windowSize, 2, 3, OptOnCost(func(index uint64, cost, threshold float64) {
+		if firstCostCall == -1 {
+			firstCostCall = calls
+		}
+	}))
+	if err != nil {
+		t.Fatalf("failed to create nunc: %s", err.Error())
+	}
+
+	for i := 0; i < 20; i++ {
+		calls++
+		n.PushVec([]float64{float64(i), float64(i) * 2, float64(i) * 3})
+	}
+
+	if firstCostCall != windowSize {
+		t.Fatalf("first aggregate cost fired on call %d, want %d", firstCostCall, windowSize)
+	}
+}
+
+func TestNUNCMultiPushVecRejectsMismatchedLength(t *testing.T) {
+	n, err := NewNUNCMulti(50, 4, 2)
+	if err != nil {
+		t.Fatalf("failed to create nunc: %s", err.Error())
+	}
+	if cp := n.PushVec([]float64{1.0}); cp != 0 {
+		t.Fatalf("PushVec with mismatched length = %d, want 0", cp)
+	}
+}
+
+func TestNUNCMultiDetectsJointChangepoint(t *testing.T) {
+	n, err := NewNUNCMulti(50, 4, 2, OptThreshold(3.0), OptAggregator(AggregatorMax))
+	if err != nil {
+		t.Fatalf("failed to create nunc: %s", err.Error())
+	}
+	r := rand.New(rand.NewSource(7))
+	found := false
+	for i := 0; i < 300; i++ {
+		a := r.NormFloat64()
+		b := r.NormFloat64()
+		if i >= 150 {
+			a += 8
+			b += 8
+		}
+		if cp := n.PushVec([]float64{a, b}); cp > 0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected a changepoint to be detected")
+	}
+}
@@ -0,0 +1,51 @@
+package nunc
+
+import "testing"
+
+// TestNUNCCostStreamPeaksAtChangepoints drives a NUNC over the synthetic
+// multi-regime dataset used elsewhere in this package (mirroring the one
+// built in example/main.go), recording every cost via OptOnCost, and asserts
+// that the single largest cost observed lands within one window size of a
+// known regime boundary.
+func TestNUNCCostStreamPeaksAtChangepoints(t *testing.T) {
+	const windowSize = 100
+	costsByIndex := make(map[uint64]float64)
+
+	n, err := NewNUNC(windowSize, 3, OptThresholdEstimate(0.02), OptOnCost(func(index uint64, cost, threshold float64) {
+		costsByIndex[index] = cost
+	}))
+	if err != nil {
+		t.Fatalf("failed to create nunc: %s", err.Error())
+	}
+
+	for _, datapoint := range syntheticRegimes(1) {
+		n.Push(datapoint)
+	}
+	if len(costsByIndex) == 0 {
+		t.Fatal("expected cost observations to be recorded")
+	}
+
+	var peakIndex uint64
+	peakCost := -1.0
+	for index, cost := range costsByIndex {
+		if cost > peakCost {
+			peakCost = cost
+			peakIndex = index
+		}
+	}
+
+	knownChangepoints := []uint64{700, 1300, 2000}
+	nearest := int64(-1)
+	for _, known := range knownChangepoints {
+		distance := int64(peakIndex) - int64(known)
+		if distance < 0 {
+			distance = -distance
+		}
+		if nearest < 0 || distance < nearest {
+			nearest = distance
+		}
+	}
+	if nearest > int64(windowSize) {
+		t.Fatalf("global cost peak at index %d (cost %f) is not within %d of any known changepoint %v", peakIndex, peakCost, windowSize, knownChangepoints)
+	}
+}
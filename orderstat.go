@@ -0,0 +1,182 @@
+package nunc
+
+import "math/rand"
+
+const (
+	orderStatMaxLevel = 32
+	orderStatP        = 0.25
+)
+
+// orderStatNode is a single entry in an orderStatSkipList. Each node tracks,
+// per level, the forward pointer at that level and the "span" of that link:
+// the number of nodes it skips over. Spans make the list indexable, allowing
+// the node holding a given rank to be located without a linear scan.
+type orderStatNode struct {
+	value   float64
+	forward []*orderStatNode
+	span    []int
+}
+
+func newOrderStatNode(value float64, level int) *orderStatNode {
+	return &orderStatNode{
+		value:   value,
+		forward: make([]*orderStatNode, level),
+		span:    make([]int, level),
+	}
+}
+
+// orderStatSkipList is an indexable skip list keyed by value that permits
+// duplicate entries (i.e. a sorted multiset). Insert and Delete run in
+// O(log n) expected time, as do the rank queries (CountLess,
+// CountLessOrEqual) and the order statistic query (Select). It backs
+// IncrementalProcessor so a NUNC window can be kept in sorted order across
+// pushes without copying and sorting the whole window every time.
+type orderStatSkipList struct {
+	head   *orderStatNode
+	level  int
+	length int
+}
+
+func newOrderStatSkipList() *orderStatSkipList {
+	return &orderStatSkipList{
+		head:  newOrderStatNode(0, orderStatMaxLevel),
+		level: 1,
+	}
+}
+
+func randomOrderStatLevel() int {
+	level := 1
+	for level < orderStatMaxLevel && rand.Float64() < orderStatP {
+		level++
+	}
+	return level
+}
+
+// Len returns the number of values currently held in the skip list.
+func (s *orderStatSkipList) Len() int {
+	return s.length
+}
+
+// Insert adds value to the skip list, maintaining sorted order. Duplicate
+// values are permitted and are kept as distinct entries.
+func (s *orderStatSkipList) Insert(value float64) {
+	update := make([]*orderStatNode, orderStatMaxLevel)
+	rank := make([]int, orderStatMaxLevel)
+
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		if i == s.level-1 {
+			rank[i] = 0
+		} else {
+			rank[i] = rank[i+1]
+		}
+		for x.forward[i] != nil && x.forward[i].value < value {
+			rank[i] += x.span[i]
+			x = x.forward[i]
+		}
+		update[i] = x
+	}
+
+	level := randomOrderStatLevel()
+	if level > s.level {
+		for i := s.level; i < level; i++ {
+			rank[i] = 0
+			update[i] = s.head
+			update[i].span[i] = s.length
+		}
+		s.level = level
+	}
+
+	node := newOrderStatNode(value, level)
+	for i := 0; i < level; i++ {
+		node.forward[i] = update[i].forward[i]
+		update[i].forward[i] = node
+
+		node.span[i] = update[i].span[i] - (rank[0] - rank[i])
+		update[i].span[i] = (rank[0] - rank[i]) + 1
+	}
+
+	for i := level; i < s.level; i++ {
+		update[i].span[i]++
+	}
+
+	s.length++
+}
+
+// Delete removes a single occurrence of value from the skip list. It returns
+// true if a matching value was found and removed, or false if the value was
+// not present.
+func (s *orderStatSkipList) Delete(value float64) bool {
+	update := make([]*orderStatNode, orderStatMaxLevel)
+
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.forward[i] != nil && x.forward[i].value < value {
+			x = x.forward[i]
+		}
+		update[i] = x
+	}
+
+	target := x.forward[0]
+	if target == nil || target.value != value {
+		return false
+	}
+
+	for i := 0; i < s.level; i++ {
+		if update[i].forward[i] == target {
+			update[i].span[i] += target.span[i] - 1
+			update[i].forward[i] = target.forward[i]
+		} else {
+			update[i].span[i]--
+		}
+	}
+	for s.level > 1 && s.head.forward[s.level-1] == nil {
+		s.level--
+	}
+
+	s.length--
+	return true
+}
+
+// Select returns the value at the given 0-indexed rank in ascending sorted
+// order. The caller must ensure 0 <= rank < s.Len().
+func (s *orderStatSkipList) Select(rank int) float64 {
+	target := rank + 1
+	traversed := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.forward[i] != nil && traversed+x.span[i] <= target {
+			traversed += x.span[i]
+			x = x.forward[i]
+		}
+	}
+	return x.value
+}
+
+// CountLess returns the number of values in the skip list strictly less than
+// value.
+func (s *orderStatSkipList) CountLess(value float64) int {
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.forward[i] != nil && x.forward[i].value < value {
+			rank += x.span[i]
+			x = x.forward[i]
+		}
+	}
+	return rank
+}
+
+// CountLessOrEqual returns the number of values in the skip list less than
+// or equal to value.
+func (s *orderStatSkipList) CountLessOrEqual(value float64) int {
+	rank := 0
+	x := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for x.forward[i] != nil && x.forward[i].value <= value {
+			rank += x.span[i]
+			x = x.forward[i]
+		}
+	}
+	return rank
+}
@@ -0,0 +1,93 @@
+package nunc
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// naiveOrderStats is a reference multiset backed by a sorted slice, used to
+// check orderStatSkipList against an obviously-correct implementation.
+type naiveOrderStats struct {
+	data []float64
+}
+
+func (n *naiveOrderStats) Insert(value float64) {
+	n.data = append(n.data, value)
+	sort.Float64s(n.data)
+}
+
+func (n *naiveOrderStats) Delete(value float64) bool {
+	for i, v := range n.data {
+		if v == value {
+			n.data = append(n.data[:i], n.data[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (n *naiveOrderStats) Select(rank int) float64 {
+	return n.data[rank]
+}
+
+func (n *naiveOrderStats) CountLess(value float64) int {
+	return sort.SearchFloat64s(n.data, value)
+}
+
+func (n *naiveOrderStats) CountLessOrEqual(value float64) int {
+	count := 0
+	for _, v := range n.data {
+		if v <= value {
+			count++
+		}
+	}
+	return count
+}
+
+func TestOrderStatSkipListMatchesNaiveReference(t *testing.T) {
+	s := newOrderStatSkipList()
+	naive := &naiveOrderStats{}
+	r := rand.New(rand.NewSource(99))
+
+	const population = 300
+	for i := 0; i < population; i++ {
+		v := math.Trunc(r.NormFloat64()*10) / 10 // some duplicate-friendly values
+		s.Insert(v)
+		naive.Insert(v)
+	}
+
+	check := func(label string) {
+		if s.Len() != len(naive.data) {
+			t.Fatalf("%s: Len() = %d, want %d", label, s.Len(), len(naive.data))
+		}
+		for i := 0; i < s.Len(); i++ {
+			if got, want := s.Select(i), naive.Select(i); got != want {
+				t.Fatalf("%s: Select(%d) = %v, want %v", label, i, got, want)
+			}
+		}
+		for _, q := range []float64{-100, naive.data[0], naive.data[len(naive.data)/2], naive.data[len(naive.data)-1], 100} {
+			if got, want := s.CountLess(q), naive.CountLess(q); got != want {
+				t.Fatalf("%s: CountLess(%v) = %d, want %d", label, q, got, want)
+			}
+			if got, want := s.CountLessOrEqual(q), naive.CountLessOrEqual(q); got != want {
+				t.Fatalf("%s: CountLessOrEqual(%v) = %d, want %d", label, q, got, want)
+			}
+		}
+	}
+	check("after inserts")
+
+	// Delete a pseudo-random third of the population and re-check.
+	for i := 0; i < population/3; i++ {
+		v := naive.data[r.Intn(len(naive.data))]
+		if got, want := s.Delete(v), naive.Delete(v); got != want {
+			t.Fatalf("Delete(%v) = %v, want %v", v, got, want)
+		}
+	}
+	check("after deletes")
+
+	if s.Delete(1e9) {
+		t.Fatal("Delete of an absent value should return false")
+	}
+}
@@ -6,13 +6,27 @@ import (
 	"sort"
 )
 
+// Processor is implemented by anything that can ingest a datapoint and
+// return its NUNC segment cost once its window is fully populated. This lets
+// a NUNC instance be driven by NuncProcessor, the naive sort-per-push
+// reference implementation, or by an alternative such as IncrementalProcessor
+// without caring which one it holds.
+type Processor interface {
+	Process(value float64, quantileCount int) (*Cost, error)
+}
+
 // NuncProcessor is a data processor for determining changepoints in data feeds.
 // Implementing the NUNC algorithm, a NUNC processor takes floting point values
 // and determines if and when a change in the data's distribution has occurred.
+// It is the naive reference implementation: every push copies and sorts the
+// whole window. See IncrementalProcessor for a processor that avoids this by
+// maintaining an order statistic structure across pushes.
 type NuncProcessor struct {
 	window *Window[float64]
 }
 
+var _ Processor = (*NuncProcessor)(nil)
+
 // Cost is an attributed "cost" of a datapoint in the feed as determined by the
 // NUNC processor. This in itself is just data, to decide if the point is or is
 // not a changepoint, the value must be compared against some threshold.
@@ -0,0 +1,34 @@
+package nunc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// BenchmarkNuncProcessor exercises the naive, sort-per-push reference
+// processor.
+func BenchmarkNuncProcessor(b *testing.B) {
+	proc, err := New(250)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkProcessor(b, proc)
+}
+
+// BenchmarkIncrementalProcessor exercises the order-statistic-backed
+// processor, for comparison against BenchmarkNuncProcessor.
+func BenchmarkIncrementalProcessor(b *testing.B) {
+	proc, err := NewIncremental(250)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkProcessor(b, proc)
+}
+
+func benchmarkProcessor(b *testing.B, proc Processor) {
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		proc.Process(r.NormFloat64(), 3)
+	}
+}
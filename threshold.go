@@ -3,6 +3,7 @@ package nunc
 import (
 	"fmt"
 	"math"
+	"sort"
 )
 
 type Threshold interface {
@@ -56,59 +57,94 @@ func (te ThresholdEstimate) Value() float64 {
 // configure, set the chance of a false changepoint detection to `probability`
 // in every N `datapoints`. To tailor this to your data processor, the window
 // size and quantile count should be set equal to the same configuration options
-// as the processor.
-func NewThresholdEstimate(probability float64, datapoints, windowSize, quantiles int) (Threshold, error) {
+// as the processor. For a multivariate NUNC, dim should be set to the number
+// of dimensions being monitored; this inflates the estimate to account for
+// the increased chance of a false alarm across `dim` independent
+// per-dimension tests. For a univariate NUNC, dim should be 1.
+func NewThresholdEstimate(probability float64, datapoints, windowSize, quantiles, dim int) (Threshold, error) {
 	if probability <= 0 || probability > 1 {
 		return nil, fmt.Errorf("probability must be greater than 0 and less than or equal to 1")
 	}
 	if windowSize <= 0 {
 		return nil, fmt.Errorf("window size must be greater than 0")
 	}
+	if dim <= 0 {
+		return nil, fmt.Errorf("dimension must be greater than 0")
+	}
 	if (datapoints + 1) == windowSize {
-		return ThresholdEstimate{value: 1.0 + (2.0 * (math.Sqrt(2.0 * (math.Log((float64(windowSize) * (float64(datapoints) - float64(windowSize) + 1.0)) / probability)))))}, nil
+		return ThresholdEstimate{value: 1.0 + (2.0 * (math.Sqrt(2.0 * (math.Log((float64(windowSize) * (float64(datapoints) - float64(windowSize) + 1.0) * float64(dim)) / probability)))))}, nil
 	}
-	estimateA := 1.0 - (8.0 * (1.0 / float64(quantiles)) * math.Log(probability/(float64(windowSize)*(float64(datapoints)-float64(windowSize)+1.0))))
-	estimateB := 1.0 + (2.0 * (math.Sqrt(2.0 * (math.Log((float64(windowSize) * (float64(datapoints) - float64(windowSize) + 1.0)) / probability)))))
+	estimateA := 1.0 - (8.0 * (1.0 / float64(quantiles)) * math.Log(probability/(float64(windowSize)*(float64(datapoints)-float64(windowSize)+1.0)*float64(dim))))
+	estimateB := 1.0 + (2.0 * (math.Sqrt(2.0 * (math.Log((float64(windowSize) * (float64(datapoints) - float64(windowSize) + 1.0) * float64(dim)) / probability)))))
 	return ThresholdEstimate{value: math.Max(estimateA, estimateB)}, nil
 }
 
-// type AutoNunc struct {
-// 	costWindow        *Window[float64]
-// 	calibrationCutoff int
-// 	thresholdPct      float64
-// 	threshold         float64
-// }
-
-// func NewAutoNunc(windowSize int, thresholdPct float64) (*AutoNunc, error) {
-// 	cw, err := NewWindow[float64](windowSize)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return &AutoNunc{
-// 		costWindow:        cw,
-// 		calibrationCutoff: 0,
-// 		thresholdPct:      thresholdPct,
-// 		threshold:         -1,
-// 	}, nil
-// }
-
-// func (an *AutoNunc) Process(processor *NuncProcessor, value float64, quantileCount int) (bool, *Cost, error) {
-// 	dataset := an.costWindow.GetFull()
-// 	if dataset != nil {
-// 		sorted := make([]float64, len(dataset))
-// 		copy(sorted, dataset)
-// 		sort.Float64s(sorted)
-// 		an.threshold = quantile(sorted, an.thresholdPct)
-// 		// fmt.Printf("NEW THRESHOLD: %f\n", an.threshold)
-// 	}
-// 	cost, err := processor.Process(value, quantileCount)
-// 	if err == nil {
-// 		an.costWindow.Push(cost.Value())
-// 	}
-// 	if an.threshold >= 0 {
-// 		if cost.value > an.threshold {
-// 			return true, cost, err
-// 		}
-// 	}
-// 	return false, cost, err
-// }
+// ThresholdAdaptive derives its threshold from the recent history of costs it
+// has observed, rather than a fixed or pre-estimated value. A rolling window
+// of past costs is maintained and, once `warmup` costs have been collected,
+// the threshold is set to the `thresholdPct` quantile of that window and is
+// refreshed every time a new cost is added to the history. Costs that are
+// themselves flagged as changepoints are not added to the history, so a real
+// distribution shift does not drag the threshold upward behind it.
+type ThresholdAdaptive struct {
+	costWindow   *Window[float64]
+	thresholdPct float64
+	warmup       int
+	value        float64
+}
+
+func (ta *ThresholdAdaptive) Changepoint(cost float64) (bool, error) {
+	warmedUp := ta.costWindow.Count() >= uint64(ta.warmup)
+	change := warmedUp && ta.value >= 0 && cost > ta.value
+	if !change {
+		ta.costWindow.Push(cost)
+		ta.refresh()
+	}
+	return change, nil
+}
+
+func (ta ThresholdAdaptive) Value() float64 {
+	return ta.value
+}
+
+// refresh recalculates the live threshold value from the current cost
+// history, once enough costs have been observed to satisfy the configured
+// warmup.
+func (ta *ThresholdAdaptive) refresh() {
+	data := ta.costWindow.Get()
+	if len(data) < ta.warmup {
+		return
+	}
+	sorted := make([]float64, len(data))
+	copy(sorted, data)
+	sort.Float64s(sorted)
+	ta.value = quantile(sorted, ta.thresholdPct)
+}
+
+// NewThresholdAdaptive creates a threshold that adapts to the recent cost
+// history of the stream it monitors. costWindowSize sets how many past costs
+// are retained, thresholdPct sets the quantile of that history used as the
+// live threshold, and warmup is the number of cost observations required
+// before the threshold becomes active. See ThresholdAdaptive for how it
+// avoids learning from its own detected changepoints.
+func NewThresholdAdaptive(costWindowSize int, thresholdPct float64, warmup int) (Threshold, error) {
+	if thresholdPct <= 0 || thresholdPct >= 1 {
+		return nil, fmt.Errorf("threshold percentile must be greater than 0 and less than 1")
+	}
+	if warmup <= 0 {
+		return nil, fmt.Errorf("warmup must be greater than 0")
+	}
+	if costWindowSize < warmup {
+		return nil, fmt.Errorf("cost window size must be greater than or equal to warmup, otherwise the cost window can never hold enough observations to satisfy it")
+	}
+	costWindow, err := NewWindow[float64](costWindowSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ThresholdAdaptive{
+		costWindow:   costWindow,
+		thresholdPct: thresholdPct,
+		warmup:       warmup,
+		value:        -1,
+	}, nil
+}
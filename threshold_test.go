@@ -0,0 +1,74 @@
+package nunc
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// syntheticRegimes builds a multi-regime dataset similar to the one used by
+// the example in example/main.go, with distribution shifts at known points.
+func syntheticRegimes(seed int64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	regimes := []struct {
+		stddev, mean float64
+		size         int
+	}{
+		{1, 0, 700},
+		{0.5, 20, 600},
+		{4, 5, 700},
+		{95, 6000, 400},
+	}
+	dist := make([]float64, 0)
+	for _, regime := range regimes {
+		for i := 0; i < regime.size; i++ {
+			dist = append(dist, r.NormFloat64()*regime.stddev+regime.mean)
+		}
+	}
+	return dist
+}
+
+func TestThresholdAdaptiveDetectsRegimeShifts(t *testing.T) {
+	n, err := NewNUNC(100, 3, OptThresholdAdaptive(50, 0.98, 50))
+	if err != nil {
+		t.Fatalf("failed to create nunc: %s", err.Error())
+	}
+	changepoints := 0
+	for _, datapoint := range syntheticRegimes(1) {
+		if cp := n.Push(datapoint); cp > 0 {
+			changepoints++
+		}
+	}
+	if changepoints == 0 {
+		t.Fatal("expected at least one changepoint to be detected across the regime shifts")
+	}
+}
+
+func TestNewThresholdAdaptiveRejectsCostWindowSmallerThanWarmup(t *testing.T) {
+	if _, err := NewThresholdAdaptive(5, 0.9, 10); err == nil {
+		t.Fatal("expected an error when costWindowSize is smaller than warmup")
+	}
+}
+
+func TestThresholdAdaptiveIgnoresItsOwnChangepoints(t *testing.T) {
+	threshold, err := NewThresholdAdaptive(20, 0.9, 10)
+	if err != nil {
+		t.Fatalf("failed to create threshold: %s", err.Error())
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := threshold.Changepoint(1.0); err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+	}
+	before := threshold.Value()
+
+	change, err := threshold.Changepoint(1000.0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !change {
+		t.Fatal("expected an extreme cost to be flagged as a changepoint")
+	}
+	if threshold.Value() != before {
+		t.Fatalf("adaptive threshold should not move after its own changepoint: got %f, want %f", threshold.Value(), before)
+	}
+}
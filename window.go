@@ -73,6 +73,19 @@ func (w *Window[T]) GetFull() []T {
 	return w.get(true)
 }
 
+// Oldest returns the value that will next be evicted from the window should
+// a new value be pushed. If the window has not yet reached capacity, there
+// is nothing to evict and the zero value and false are returned.
+func (w *Window[T]) Oldest() (T, bool) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if !w.Full() {
+		var zero T
+		return zero, false
+	}
+	return w.data[w.marker()], true
+}
+
 // Len returns the number of elements that are in the window. If the window is
 // full, this will be equal to the capacity.
 func (w *Window[T]) Len() uint64 {